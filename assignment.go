@@ -0,0 +1,221 @@
+package pknulms
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// Attachment represents a single file attached to an assignment, either
+// provided by the professor as material or uploaded as part of a submission.
+type Attachment struct {
+	Name string
+	URL  string
+	Size int64
+}
+
+// Assignment represents the detail page of an assignment notification.
+type Assignment struct {
+	Deadline          string
+	Description       string
+	MaxFileSize       int64
+	AllowedExtensions []string
+	Attachments       []*Attachment
+}
+
+// SubmitRequest carries the payload submitted for an assignment.
+type SubmitRequest struct {
+	Content string
+	Uploads []io.Reader
+}
+
+// GetAssignment fetches and parses the detail page of an assignment notification n.
+// n.Type must be "과제".
+func (c *Client) GetAssignment(n *Notification) (*Assignment, error) {
+	return c.GetAssignmentContext(context.Background(), n)
+}
+
+// GetAssignmentContext fetches and parses the detail page of an assignment
+// notification n, with given context. n.Type must be "과제".
+func (c *Client) GetAssignmentContext(ctx context.Context, n *Notification) (*Assignment, error) {
+	ctx, cancel := c.withDeadlines(ctx)
+	defer cancel()
+
+	if n.Type != "과제" {
+		return nil, fmt.Errorf("notification %s is not an assignment", n)
+	}
+
+	if err := c.prefetchArticle(ctx, n.Lecture.Key, strings.TrimPrefix(n.Link, c.baseURL)); err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, n.Link+"&s=menu&acl=", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	doc, err := goquery.NewDocumentFromResponse(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	a := &Assignment{
+		Deadline:    strings.TrimSpace(doc.Find(".assignmentview .deadline").Text()),
+		Description: strings.TrimSpace(doc.Find(".assignmentview .textviewer").Text()),
+	}
+
+	if extText := strings.TrimSpace(doc.Find(".assignmentview .allowedExt").Text()); extText != "" {
+		for _, ext := range strings.Split(extText, ",") {
+			a.AllowedExtensions = append(a.AllowedExtensions, strings.TrimSpace(ext))
+		}
+	}
+
+	if sizeText := strings.TrimSpace(doc.Find(".assignmentview .maxFileSize").Text()); sizeText != "" {
+		if size, err := parseByteSize(sizeText); err == nil {
+			a.MaxFileSize = size
+		}
+	}
+
+	doc.Find(".assignmentview .attachList li").Each(func(i int, li *goquery.Selection) {
+		link := li.Find("a").First()
+		href, exists := link.Attr("href")
+		if !exists {
+			return
+		}
+
+		attachment := &Attachment{
+			Name: strings.TrimSpace(link.Text()),
+			URL:  c.baseURL + href,
+		}
+		if sizeText := strings.TrimSpace(li.Find(".size").Text()); sizeText != "" {
+			if size, err := parseByteSize(sizeText); err == nil {
+				attachment.Size = size
+			}
+		}
+		a.Attachments = append(a.Attachments, attachment)
+	})
+
+	return a, nil
+}
+
+// byteSizeRe matches a human-readable file size such as "1.2MB" or "512KB".
+var byteSizeRe = regexp.MustCompile(`(?i)^([\d.]+)\s*(b|kb|mb|gb)?$`)
+
+// parseByteSize parses a human-readable file size like "1.2MB" into bytes.
+func parseByteSize(s string) (int64, error) {
+	m := byteSizeRe.FindStringSubmatch(strings.TrimSpace(s))
+	if m == nil {
+		return 0, fmt.Errorf("unrecognized file size %q", s)
+	}
+
+	value, err := strconv.ParseFloat(m[1], 64)
+	if err != nil {
+		return 0, err
+	}
+
+	unit := float64(1)
+	switch strings.ToLower(m[2]) {
+	case "kb":
+		unit = 1024
+	case "mb":
+		unit = 1024 * 1024
+	case "gb":
+		unit = 1024 * 1024 * 1024
+	}
+
+	return int64(value * unit), nil
+}
+
+// DownloadAttachment streams the content of a through the authenticated
+// client, writing it to w.
+func (c *Client) DownloadAttachment(ctx context.Context, a *Attachment, w io.Writer) error {
+	ctx, cancel := c.withDeadlines(ctx)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, a.URL, nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != 200 {
+		return fmt.Errorf("Expected HTTP status code 200, got %d", resp.StatusCode)
+	}
+
+	_, err = io.Copy(w, resp.Body)
+	return err
+}
+
+// SubmitAssignment submits req for the assignment notification n.
+func (c *Client) SubmitAssignment(ctx context.Context, n *Notification, req SubmitRequest) error {
+	ctx, cancel := c.withDeadlines(ctx)
+	defer cancel()
+
+	var body bytes.Buffer
+	mw := multipart.NewWriter(&body)
+
+	if err := mw.WriteField("KJKEY", n.Lecture.Key); err != nil {
+		return err
+	}
+	if err := mw.WriteField("CONTENT", req.Content); err != nil {
+		return err
+	}
+	if err := mw.WriteField("encoding", "utf-8"); err != nil {
+		return err
+	}
+	for i, upload := range req.Uploads {
+		fw, err := mw.CreateFormFile(fmt.Sprintf("up_file%d", i+1), fmt.Sprintf("upload%d", i+1))
+		if err != nil {
+			return err
+		}
+		if _, err := io.Copy(fw, upload); err != nil {
+			return err
+		}
+	}
+	if err := mw.Close(); err != nil {
+		return err
+	}
+
+	endpoint := "/ilos/st/course/report_submit_ok.acl"
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, c.baseURL+endpoint, &body)
+	if err != nil {
+		return err
+	}
+	httpReq.Header.Set("Content-Type", mw.FormDataContentType())
+
+	resp, err := c.httpClient.Do(httpReq)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	var result lmsEnvelope
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return err
+	}
+	if result.IsError {
+		return &LMSError{Message: result.Message, Endpoint: endpoint}
+	}
+
+	return nil
+}
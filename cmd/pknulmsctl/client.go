@@ -0,0 +1,69 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/hallazzang/pknulms"
+	"github.com/urfave/cli/v2"
+)
+
+// sessionPath returns the path the session cookie jar is persisted to.
+func sessionPath() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "pknulmsctl", "session.json"), nil
+}
+
+// newClient creates a fresh, unauthenticated client.
+func newClient(ctx *cli.Context) (*pknulms.Client, error) {
+	return pknulms.NewClient()
+}
+
+// loadClient creates a client and restores its session from disk, so the
+// caller doesn't need to log in again on every invocation.
+func loadClient(ctx *cli.Context) (*pknulms.Client, error) {
+	c, err := newClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	path, err := sessionPath()
+	if err != nil {
+		return nil, err
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	if err := c.LoadSession(f); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// saveClientSession persists c's session to disk, creating the config
+// directory if necessary.
+func saveClientSession(c *pknulms.Client) error {
+	path, err := sessionPath()
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return err
+	}
+
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	return c.SaveSession(f)
+}
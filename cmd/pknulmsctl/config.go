@@ -0,0 +1,105 @@
+package main
+
+import (
+	"fmt"
+	"net"
+	"net/smtp"
+	"os"
+	"text/template"
+
+	"github.com/hallazzang/pknulms"
+	"github.com/hallazzang/pknulms/dispatch"
+	"gopkg.in/yaml.v3"
+)
+
+// config is the shape of the YAML file passed to "watch --config".
+type config struct {
+	Template string       `yaml:"template"`
+	OnlyType string       `yaml:"only_type"`
+	Sinks    []sinkConfig `yaml:"sinks"`
+}
+
+type sinkConfig struct {
+	Type string `yaml:"type"`
+
+	// smtp
+	Addr     string   `yaml:"addr"`
+	Username string   `yaml:"username"`
+	Password string   `yaml:"password"`
+	From     string   `yaml:"from"`
+	To       []string `yaml:"to"`
+
+	// webhook / discord
+	URL string `yaml:"url"`
+
+	// file
+	Path string `yaml:"path"`
+}
+
+func loadConfig(path string) (*config, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var cfg config
+	if err := yaml.NewDecoder(f).Decode(&cfg); err != nil {
+		return nil, err
+	}
+	return &cfg, nil
+}
+
+// Dispatcher builds a dispatch.Dispatcher from the config.
+func (cfg *config) Dispatcher() (*dispatch.Dispatcher, error) {
+	var tmpl *template.Template
+	if cfg.Template != "" {
+		var err error
+		tmpl, err = template.New("notification").Parse(cfg.Template)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var filter dispatch.Filter
+	if cfg.OnlyType != "" {
+		onlyType := cfg.OnlyType
+		filter = func(n *pknulms.Notification) bool {
+			return n.Type == onlyType
+		}
+	}
+
+	sinks := make([]dispatch.Sink, 0, len(cfg.Sinks))
+	for _, s := range cfg.Sinks {
+		sink, err := s.build()
+		if err != nil {
+			return nil, err
+		}
+		sinks = append(sinks, sink)
+	}
+
+	return dispatch.NewDispatcher(sinks, filter, tmpl)
+}
+
+func (s *sinkConfig) build() (dispatch.Sink, error) {
+	switch s.Type {
+	case "smtp":
+		var auth smtp.Auth
+		if s.Username != "" {
+			host, _, err := net.SplitHostPort(s.Addr)
+			if err != nil {
+				return nil, err
+			}
+			auth = smtp.PlainAuth("", s.Username, s.Password, host)
+		}
+		return &dispatch.SMTPSink{Addr: s.Addr, Auth: auth, From: s.From, To: s.To}, nil
+	case "webhook":
+		return &dispatch.WebhookSink{URL: s.URL}, nil
+	case "discord":
+		return &dispatch.DiscordWebhookSink{URL: s.URL}, nil
+	case "file":
+		return &dispatch.FileSink{Path: s.Path}, nil
+	default:
+		return nil, fmt.Errorf("unknown sink type %q", s.Type)
+	}
+}
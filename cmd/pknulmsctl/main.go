@@ -0,0 +1,232 @@
+// Command pknulmsctl is a command-line client for the Pukyong National
+// University LMS, built on top of the pknulms package.
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"strconv"
+	"text/tabwriter"
+	"time"
+
+	"github.com/hallazzang/pknulms"
+	"github.com/urfave/cli/v2"
+)
+
+func main() {
+	app := &cli.App{
+		Name:  "pknulmsctl",
+		Usage: "a command-line client for the PKNU LMS",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:    "id",
+				Usage:   "LMS login ID",
+				EnvVars: []string{"PKNULMS_ID"},
+			},
+			&cli.StringFlag{
+				Name:    "password",
+				Usage:   "LMS login password",
+				EnvVars: []string{"PKNULMS_PW"},
+			},
+		},
+		Commands: []*cli.Command{
+			loginCommand,
+			logoutCommand,
+			listCommand,
+			showCommand,
+			noteCommand,
+			watchCommand,
+		},
+	}
+
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer cancel()
+
+	if err := app.RunContext(ctx, os.Args); err != nil {
+		log.Fatal(err)
+	}
+}
+
+var loginCommand = &cli.Command{
+	Name:  "login",
+	Usage: "log in to the LMS and save the session",
+	Action: func(ctx *cli.Context) error {
+		c, err := newClient(ctx)
+		if err != nil {
+			return err
+		}
+
+		ok, err := c.LoginContext(ctx.Context, ctx.String("id"), ctx.String("password"))
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return fmt.Errorf("login failed: wrong ID or password")
+		}
+
+		return saveClientSession(c)
+	},
+}
+
+var logoutCommand = &cli.Command{
+	Name:  "logout",
+	Usage: "log out from the LMS and remove the saved session",
+	Action: func(ctx *cli.Context) error {
+		c, err := loadClient(ctx)
+		if err != nil {
+			return err
+		}
+
+		if err := c.LogoutContext(ctx.Context); err != nil {
+			return err
+		}
+
+		path, err := sessionPath()
+		if err != nil {
+			return err
+		}
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		return nil
+	},
+}
+
+var listCommand = &cli.Command{
+	Name:  "list",
+	Usage: "list notifications",
+	Flags: []cli.Flag{
+		&cli.IntFlag{Name: "page", Value: 1, Usage: "page number"},
+		&cli.IntFlag{Name: "count", Value: 20, Usage: "notifications per page (must be >= 8)"},
+		&cli.BoolFlag{Name: "json", Usage: "print as JSON"},
+	},
+	Action: func(ctx *cli.Context) error {
+		c, err := loadClient(ctx)
+		if err != nil {
+			return err
+		}
+
+		notifs, err := c.GetNotificationsContext(ctx.Context, (ctx.Int("page")-1)*ctx.Int("count")+1, ctx.Int("count"))
+		if err != nil {
+			return err
+		}
+
+		if ctx.Bool("json") {
+			return json.NewEncoder(os.Stdout).Encode(notifs)
+		}
+
+		tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(tw, "ID\tTYPE\tLECTURE\tTITLE\tDATETIME")
+		for _, n := range notifs {
+			fmt.Fprintf(tw, "%d\t%s\t%s\t%s\t%s\n", n.ID, n.Type, n.Lecture.Name, n.Title, n.Datetime)
+		}
+		return tw.Flush()
+	},
+}
+
+var showCommand = &cli.Command{
+	Name:      "show",
+	Usage:     "show the content of a notification",
+	ArgsUsage: "<id>",
+	Action: func(ctx *cli.Context) error {
+		if ctx.NArg() != 1 {
+			return fmt.Errorf("show requires exactly one argument: <id>")
+		}
+		id, err := strconv.Atoi(ctx.Args().First())
+		if err != nil {
+			return fmt.Errorf("invalid id %q: %w", ctx.Args().First(), err)
+		}
+
+		c, err := loadClient(ctx)
+		if err != nil {
+			return err
+		}
+
+		n, err := findNotification(ctx.Context, c, id)
+		if err != nil {
+			return err
+		}
+
+		content, err := c.GetNotificationContentContext(ctx.Context, n)
+		if err != nil {
+			return err
+		}
+
+		fmt.Println(content)
+		return nil
+	},
+}
+
+var noteCommand = &cli.Command{
+	Name:  "note",
+	Usage: "send a note to a person",
+	Flags: []cli.Flag{
+		&cli.StringFlag{Name: "to", Required: true, Usage: "recipient ID"},
+		&cli.StringFlag{Name: "title", Required: true, Usage: "note title"},
+		&cli.StringFlag{Name: "content", Required: true, Usage: "note content"},
+	},
+	Action: func(ctx *cli.Context) error {
+		c, err := loadClient(ctx)
+		if err != nil {
+			return err
+		}
+
+		return c.SendNoteContext(ctx.Context, ctx.String("to"), ctx.String("title"), ctx.String("content"))
+	},
+}
+
+var watchCommand = &cli.Command{
+	Name:  "watch",
+	Usage: "stream notifications and dispatch them to configured sinks",
+	Flags: []cli.Flag{
+		&cli.DurationFlag{Name: "interval", Value: 30 * time.Second, Usage: "polling interval"},
+		&cli.StringFlag{Name: "config", Value: "pknulmsctl.yaml", Usage: "dispatch config file"},
+	},
+	Action: func(ctx *cli.Context) error {
+		c, err := loadClient(ctx)
+		if err != nil {
+			return err
+		}
+
+		cfg, err := loadConfig(ctx.String("config"))
+		if err != nil {
+			return err
+		}
+
+		d, err := cfg.Dispatcher()
+		if err != nil {
+			return err
+		}
+
+		events, err := c.StreamNotifications(ctx.Context, ctx.Duration("interval"))
+		if err != nil {
+			return err
+		}
+
+		return d.Run(ctx.Context, events)
+	},
+}
+
+// findNotification looks through the first few pages of notifications for
+// the one with the given id, since the LMS has no direct "get by id" endpoint.
+func findNotification(ctx context.Context, c *pknulms.Client, id int) (*pknulms.Notification, error) {
+	for page := 1; page <= 5; page++ {
+		notifs, err := c.GetNotificationsContext(ctx, (page-1)*20+1, 20)
+		if err != nil {
+			return nil, err
+		}
+		if len(notifs) == 0 {
+			break
+		}
+		for _, n := range notifs {
+			if n.ID == id {
+				return n, nil
+			}
+		}
+	}
+	return nil, fmt.Errorf("notification #%d not found in the first 5 pages", id)
+}
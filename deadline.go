@@ -0,0 +1,70 @@
+package pknulms
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// deadlineTimer arms a timer for a deadline and closes a channel when it
+// fires, mirroring the pattern net.Conn implementations use for
+// SetReadDeadline/SetWriteDeadline: a pending deadline cancels whatever is
+// waiting on it rather than the timer being consulted directly.
+type deadlineTimer struct {
+	mu       sync.Mutex
+	deadline time.Time
+	timer    *time.Timer
+	done     chan struct{}
+}
+
+func newDeadlineTimer() *deadlineTimer {
+	return &deadlineTimer{done: make(chan struct{})}
+}
+
+// set arms the timer for t, replacing any previously armed timer.
+// A zero t disarms the timer.
+func (d *deadlineTimer) set(t time.Time) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.timer != nil {
+		d.timer.Stop()
+	}
+	d.deadline = t
+	d.done = make(chan struct{})
+	if t.IsZero() {
+		return
+	}
+
+	dur := time.Until(t)
+	if dur <= 0 {
+		close(d.done)
+		return
+	}
+	done := d.done
+	d.timer = time.AfterFunc(dur, func() {
+		close(done)
+	})
+}
+
+// withDeadline returns a context derived from parent that is cancelled when
+// the armed deadline fires.
+func (d *deadlineTimer) withDeadline(parent context.Context) (context.Context, context.CancelFunc) {
+	d.mu.Lock()
+	deadline, done := d.deadline, d.done
+	d.mu.Unlock()
+
+	if deadline.IsZero() {
+		return context.WithCancel(parent)
+	}
+
+	ctx, cancel := context.WithCancel(parent)
+	go func() {
+		select {
+		case <-done:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+	return ctx, cancel
+}
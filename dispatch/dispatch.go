@@ -0,0 +1,134 @@
+// Package dispatch fans notifications out to a configurable list of sinks,
+// turning pknulms into the foundation of a notification daemon.
+package dispatch
+
+import (
+	"bytes"
+	"context"
+	"text/template"
+	"time"
+
+	"github.com/hallazzang/pknulms"
+)
+
+// Sink delivers a rendered notification somewhere, e.g. email or a webhook.
+type Sink interface {
+	Deliver(ctx context.Context, n *pknulms.Notification, rendered string) error
+}
+
+// Filter reports whether n should be delivered. A nil Filter delivers everything.
+type Filter func(n *pknulms.Notification) bool
+
+// Dispatcher fans notifications out to a list of Sinks, rendering each
+// message with a text/template and retrying failed deliveries with backoff.
+type Dispatcher struct {
+	Sinks    []Sink
+	Filter   Filter
+	Template *template.Template
+
+	// Retries is the number of additional delivery attempts after the first
+	// failure. Defaults to 2 when zero.
+	Retries int
+	// Backoff is the delay before the first retry, doubling on each
+	// subsequent attempt. Defaults to time.Second when zero.
+	Backoff time.Duration
+
+	// OnError, if set, is called by Run with a notification that failed to
+	// dispatch after all sinks and retries were exhausted. If nil, the error
+	// is silently dropped so a single bad delivery doesn't take down the
+	// whole stream.
+	OnError func(n *pknulms.Notification, err error)
+}
+
+// DefaultTemplate is used by NewDispatcher when no template is given.
+const DefaultTemplate = `[{{.Type}}] {{.Title}} ({{.Lecture.Name}})`
+
+// NewDispatcher creates a Dispatcher delivering to sinks, using tmpl to
+// render each notification. If tmpl is nil, DefaultTemplate is used.
+func NewDispatcher(sinks []Sink, filter Filter, tmpl *template.Template) (*Dispatcher, error) {
+	if tmpl == nil {
+		var err error
+		tmpl, err = template.New("notification").Parse(DefaultTemplate)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &Dispatcher{
+		Sinks:    sinks,
+		Filter:   filter,
+		Template: tmpl,
+	}, nil
+}
+
+// Dispatch renders n and delivers it to every configured Sink, retrying each
+// sink independently on failure. It returns the first error encountered
+// after all sinks and retries have been exhausted, if any.
+func (d *Dispatcher) Dispatch(ctx context.Context, n *pknulms.Notification) error {
+	if d.Filter != nil && !d.Filter(n) {
+		return nil
+	}
+
+	var buf bytes.Buffer
+	if err := d.Template.Execute(&buf, n); err != nil {
+		return err
+	}
+	rendered := buf.String()
+
+	var firstErr error
+	for _, sink := range d.Sinks {
+		if err := d.deliverWithRetry(ctx, sink, n, rendered); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
+// Run consumes notifications from events, e.g. the channel returned by
+// Client.StreamNotifications, and dispatches them until events is closed or
+// ctx is cancelled. A notification that fails to dispatch is reported to
+// OnError, if set, rather than stopping the loop, so a daemon built on Run
+// keeps running after a single delivery failure.
+func (d *Dispatcher) Run(ctx context.Context, events <-chan pknulms.NotificationEvent) error {
+	for {
+		select {
+		case e, ok := <-events:
+			if !ok {
+				return nil
+			}
+			if err := d.Dispatch(ctx, e.Notification); err != nil && d.OnError != nil {
+				d.OnError(e.Notification, err)
+			}
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+func (d *Dispatcher) deliverWithRetry(ctx context.Context, sink Sink, n *pknulms.Notification, rendered string) error {
+	retries := d.Retries
+	if retries == 0 {
+		retries = 2
+	}
+	backoff := d.Backoff
+	if backoff == 0 {
+		backoff = time.Second
+	}
+
+	var err error
+	for attempt := 0; attempt <= retries; attempt++ {
+		if attempt > 0 {
+			select {
+			case <-time.After(backoff):
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+			backoff *= 2
+		}
+
+		if err = sink.Deliver(ctx, n, rendered); err == nil {
+			return nil
+		}
+	}
+	return err
+}
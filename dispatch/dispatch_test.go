@@ -0,0 +1,62 @@
+package dispatch
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/hallazzang/pknulms"
+)
+
+type failingSink struct{ calls int }
+
+func (s *failingSink) Deliver(ctx context.Context, n *pknulms.Notification, rendered string) error {
+	s.calls++
+	return errors.New("delivery failed")
+}
+
+type recordingSink struct{ rendered []string }
+
+func (s *recordingSink) Deliver(ctx context.Context, n *pknulms.Notification, rendered string) error {
+	s.rendered = append(s.rendered, rendered)
+	return nil
+}
+
+func TestRunContinuesAfterDeliveryFailure(t *testing.T) {
+	failing := &failingSink{}
+	recording := &recordingSink{}
+
+	d, err := NewDispatcher([]Sink{failing, recording}, nil, nil)
+	if err != nil {
+		t.Fatalf("NewDispatcher: %v", err)
+	}
+	// A zero Retries means the default of 2 retries, so each notification
+	// reaches the failing sink 3 times.
+	d.Retries = 0
+	d.Backoff = time.Millisecond
+
+	var errs []error
+	d.OnError = func(n *pknulms.Notification, err error) {
+		errs = append(errs, err)
+	}
+
+	events := make(chan pknulms.NotificationEvent, 2)
+	events <- pknulms.NotificationEvent{Notification: &pknulms.Notification{Title: "first", Lecture: &pknulms.Lecture{}}}
+	events <- pknulms.NotificationEvent{Notification: &pknulms.Notification{Title: "second", Lecture: &pknulms.Lecture{}}}
+	close(events)
+
+	if err := d.Run(context.Background(), events); err != nil {
+		t.Fatalf("Run returned error: %v", err)
+	}
+
+	if failing.calls != 6 {
+		t.Errorf("failing sink called %d times, want 6", failing.calls)
+	}
+	if len(errs) != 2 {
+		t.Fatalf("OnError called %d times, want 2", len(errs))
+	}
+	if len(recording.rendered) != 2 {
+		t.Fatalf("recording sink delivered %d notifications, want 2", len(recording.rendered))
+	}
+}
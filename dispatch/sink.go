@@ -0,0 +1,121 @@
+package dispatch
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/smtp"
+	"os"
+
+	"github.com/hallazzang/pknulms"
+)
+
+// SMTPSink delivers notifications as plain text email via an SMTP server.
+type SMTPSink struct {
+	Addr    string
+	Auth    smtp.Auth
+	From    string
+	To      []string
+	Subject string
+}
+
+// Deliver sends rendered as the body of an email to s.To.
+func (s *SMTPSink) Deliver(ctx context.Context, n *pknulms.Notification, rendered string) error {
+	subject := s.Subject
+	if subject == "" {
+		subject = fmt.Sprintf("[pknulms] %s", n.Title)
+	}
+
+	msg := fmt.Sprintf("Subject: %s\r\n\r\n%s\r\n", subject, rendered)
+	return smtp.SendMail(s.Addr, s.Auth, s.From, s.To, []byte(msg))
+}
+
+// WebhookSink POSTs the rendered notification as JSON to a URL.
+type WebhookSink struct {
+	URL        string
+	HTTPClient *http.Client
+}
+
+type webhookPayload struct {
+	Notification *pknulms.Notification `json:"notification"`
+	Rendered     string                `json:"rendered"`
+}
+
+// Deliver POSTs n and rendered as JSON to w.URL.
+func (w *WebhookSink) Deliver(ctx context.Context, n *pknulms.Notification, rendered string) error {
+	body, err := json.Marshal(webhookPayload{Notification: n, Rendered: rendered})
+	if err != nil {
+		return err
+	}
+
+	return w.post(ctx, w.URL, "application/json", body)
+}
+
+func (w *WebhookSink) post(ctx context.Context, url, contentType string, body []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", contentType)
+
+	client := w.HTTPClient
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook: unexpected HTTP status code %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// DiscordWebhookSink posts the rendered notification as a Discord webhook message.
+type DiscordWebhookSink struct {
+	URL        string
+	HTTPClient *http.Client
+}
+
+type discordPayload struct {
+	Content string `json:"content"`
+}
+
+// Deliver posts rendered as the content of a Discord webhook message.
+func (d *DiscordWebhookSink) Deliver(ctx context.Context, n *pknulms.Notification, rendered string) error {
+	body, err := json.Marshal(discordPayload{Content: rendered})
+	if err != nil {
+		return err
+	}
+
+	w := &WebhookSink{URL: d.URL, HTTPClient: d.HTTPClient}
+	return w.post(ctx, d.URL, "application/json", body)
+}
+
+// FileSink appends the rendered notification to a file, one per line.
+type FileSink struct {
+	Path string
+}
+
+// Deliver opens f.Path in append mode and writes rendered followed by a newline.
+func (f *FileSink) Deliver(ctx context.Context, n *pknulms.Notification, rendered string) error {
+	file, err := os.OpenFile(f.Path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	return writeLine(file, rendered)
+}
+
+func writeLine(w io.Writer, s string) error {
+	_, err := io.WriteString(w, s+"\n")
+	return err
+}
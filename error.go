@@ -0,0 +1,19 @@
+package pknulms
+
+import "fmt"
+
+// LMSError represents an error reported by the LMS itself, decoded from the
+// {isError, message} JSON envelope most of its endpoints reply with.
+type LMSError struct {
+	// Message is the human-readable message the LMS reported.
+	Message string
+	// Endpoint is the request path that produced the error.
+	Endpoint string
+}
+
+func (e *LMSError) Error() string {
+	if e.Endpoint != "" {
+		return fmt.Sprintf("pknulms: %s: %s", e.Endpoint, e.Message)
+	}
+	return fmt.Sprintf("pknulms: %s", e.Message)
+}
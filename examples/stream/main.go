@@ -0,0 +1,41 @@
+// Command stream prints notification events as they are observed, until
+// interrupted with Ctrl-C.
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"time"
+
+	"github.com/hallazzang/pknulms"
+)
+
+func main() {
+	c := pknulms.MustNewClient()
+	if !c.MustLogin(os.Getenv("PKNULMS_ID"), os.Getenv("PKNULMS_PW")) {
+		log.Fatal("login failed")
+	}
+	defer c.MustLogout()
+
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer cancel()
+
+	events, err := c.StreamNotifications(ctx, 30*time.Second)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	for e := range events {
+		switch e.Type {
+		case pknulms.EventCreate:
+			fmt.Printf("created: %s\n", e.Notification)
+		case pknulms.EventUpdate:
+			fmt.Printf("updated: %s\n", e.Notification)
+		case pknulms.EventDelete:
+			fmt.Printf("deleted: #%d\n", e.Notification.ID)
+		}
+	}
+}
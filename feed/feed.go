@@ -0,0 +1,132 @@
+// Package feed turns pknulms notifications into an Atom feed, so a legacy
+// LMS portal can be bridged into a modern feed reader.
+package feed
+
+import (
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/hallazzang/pknulms"
+)
+
+// FeedOptions configures the feed document built by BuildAtom.
+type FeedOptions struct {
+	// Title is the feed's <title>.
+	Title string
+	// ID is the feed's <id>, typically a stable URL identifying the feed.
+	ID string
+	// SelfLink is the URL the feed is served from.
+	SelfLink string
+}
+
+type atomFeed struct {
+	XMLName xml.Name    `xml:"http://www.w3.org/2005/Atom feed"`
+	Title   string      `xml:"title"`
+	ID      string      `xml:"id"`
+	Updated string      `xml:"updated"`
+	Link    atomLink    `xml:"link"`
+	Entries []atomEntry `xml:"entry"`
+}
+
+type atomLink struct {
+	Href string `xml:"href,attr"`
+	Rel  string `xml:"rel,attr,omitempty"`
+}
+
+type atomEntry struct {
+	ID       string       `xml:"id"`
+	Title    string       `xml:"title"`
+	Updated  string       `xml:"updated"`
+	Author   atomAuthor   `xml:"author"`
+	Category atomCategory `xml:"category"`
+	Summary  string       `xml:"summary"`
+}
+
+type atomAuthor struct {
+	Name string `xml:"name"`
+}
+
+type atomCategory struct {
+	Term string `xml:"term,attr"`
+}
+
+// BuildAtom turns notifs into an Atom 1.0 feed document. Each notification
+// becomes an <entry> whose id is the LMS URL, title combines the
+// notification's Type and Title, updated is parsed from Datetime, author is
+// Professor, and category is Lecture.Name.
+func BuildAtom(notifs []*pknulms.Notification, opts FeedOptions) ([]byte, error) {
+	feed := atomFeed{
+		Title:   opts.Title,
+		ID:      opts.ID,
+		Updated: time.Now().UTC().Format(time.RFC3339),
+		Link:    atomLink{Href: opts.SelfLink, Rel: "self"},
+	}
+
+	for _, n := range notifs {
+		feed.Entries = append(feed.Entries, atomEntry{
+			ID:       n.Link,
+			Title:    fmt.Sprintf("%s: %s", n.Type, n.Title),
+			Updated:  parseDatetime(n.Datetime),
+			Author:   atomAuthor{Name: n.Professor},
+			Category: atomCategory{Term: n.Lecture.Name},
+			Summary:  n.PreviewContent,
+		})
+	}
+
+	out, err := xml.MarshalIndent(feed, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(xml.Header), out...), nil
+}
+
+// knownDatetimeLayouts lists the Datetime formats observed across
+// notification types, tried in order.
+var knownDatetimeLayouts = []string{
+	"2006.01.02 15:04",
+	"2006.01.02",
+}
+
+// parseDatetime best-effort parses a Notification's Datetime field into an
+// RFC 3339 timestamp for use in Atom's <updated>. If none of the known
+// layouts match, the original string is returned unchanged.
+func parseDatetime(s string) string {
+	for _, layout := range knownDatetimeLayouts {
+		if t, err := time.ParseInLocation(layout, s, time.Local); err == nil {
+			return t.Format(time.RFC3339)
+		}
+	}
+	return s
+}
+
+// ServeHTTP returns an http.Handler that serves c's notifications as an
+// Atom feed, fetching a fresh page from the LMS on every request. The page
+// to serve can be selected with the "page" query parameter, defaulting to 1.
+func ServeHTTP(c *pknulms.Client, opts FeedOptions) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		page := 1
+		if p := r.URL.Query().Get("page"); p != "" {
+			if v, err := strconv.Atoi(p); err == nil {
+				page = v
+			}
+		}
+
+		notifs, err := c.GetNotificationsByPage(page)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadGateway)
+			return
+		}
+
+		body, err := BuildAtom(notifs, opts)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/atom+xml; charset=utf-8")
+		w.Write(body)
+	})
+}
@@ -2,46 +2,144 @@
 package pknulms
 
 import (
+	"context"
 	"crypto/tls"
 	"fmt"
 	"io/ioutil"
 	"net/http"
 	"net/http/cookiejar"
-	"net/url"
 	"strings"
+	"time"
 )
 
+// defaultBaseURL is used when NewClient is not given WithBaseURL.
+const defaultBaseURL = "https://lms.pknu.ac.kr"
+
 // Client is a wrapper for a single http.Client instance.
 type Client struct {
 	httpClient *http.Client
+	baseURL    string
+	userAgent  string
+
+	readDeadline  *deadlineTimer
+	writeDeadline *deadlineTimer
 }
 
-// NewClient creates a new LMS client.
-func NewClient() (*Client, error) {
-	jar, err := cookiejar.New(nil)
-	if err != nil {
-		return nil, err
+// clientConfig accumulates ClientOptions before NewClient builds a Client from it.
+type clientConfig struct {
+	httpClient  *http.Client
+	baseURL     string
+	userAgent   string
+	insecureTLS bool
+}
+
+// ClientOption configures a Client created by NewClient.
+type ClientOption func(*clientConfig)
+
+// WithInsecureTLS disables TLS certificate verification. The library is
+// safe-by-default, so this must be opted into explicitly.
+func WithInsecureTLS() ClientOption {
+	return func(cfg *clientConfig) {
+		cfg.insecureTLS = true
+	}
+}
+
+// WithHTTPClient makes the Client use hc instead of building its own,
+// e.g. to point it at a mock server in tests. The caller is responsible for
+// configuring hc's cookie jar and redirect policy.
+func WithHTTPClient(hc *http.Client) ClientOption {
+	return func(cfg *clientConfig) {
+		cfg.httpClient = hc
+	}
+}
+
+// WithBaseURL overrides the LMS base URL, e.g. to point the Client at a mock
+// server in tests.
+func WithBaseURL(baseURL string) ClientOption {
+	return func(cfg *clientConfig) {
+		cfg.baseURL = strings.TrimSuffix(baseURL, "/")
 	}
+}
+
+// WithUserAgent sets the User-Agent header sent with the requests of Login,
+// Logout, GetNotifications, SendNote and the article prefetch. The article
+// page fetched by GetNotificationContent and GetAssignment, and the requests
+// of DownloadAttachment and SubmitAssignment, are sent without it.
+func WithUserAgent(userAgent string) ClientOption {
+	return func(cfg *clientConfig) {
+		cfg.userAgent = userAgent
+	}
+}
 
-	tr := &http.Transport{
-		TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
+// NewClient creates a new LMS client, safe by default: TLS certificates are
+// verified unless WithInsecureTLS is given.
+func NewClient(opts ...ClientOption) (*Client, error) {
+	cfg := &clientConfig{baseURL: defaultBaseURL}
+	for _, opt := range opts {
+		opt(cfg)
 	}
 
-	c := new(Client)
-	c.httpClient = &http.Client{
-		Transport: tr,
-		Jar:       jar,
-		CheckRedirect: func(req *http.Request, via []*http.Request) error {
-			return http.ErrUseLastResponse
-		},
+	httpClient := cfg.httpClient
+	if httpClient == nil {
+		jar, err := cookiejar.New(nil)
+		if err != nil {
+			return nil, err
+		}
+
+		tr := &http.Transport{}
+		if cfg.insecureTLS {
+			tr.TLSClientConfig = &tls.Config{InsecureSkipVerify: true}
+		}
+
+		httpClient = &http.Client{
+			Transport: tr,
+			Jar:       jar,
+			CheckRedirect: func(req *http.Request, via []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		}
 	}
 
-	return c, nil
+	return &Client{
+		httpClient:    httpClient,
+		baseURL:       cfg.baseURL,
+		userAgent:     cfg.userAgent,
+		readDeadline:  newDeadlineTimer(),
+		writeDeadline: newDeadlineTimer(),
+	}, nil
+}
+
+// SetReadDeadline sets the read deadline for subsequent ...Context calls.
+// A pending call is cancelled as soon as the deadline fires, whether it is
+// still sending its request or already reading the response; the read and
+// write deadlines differ only in which setter arms them. A zero value
+// disables the read deadline.
+func (c *Client) SetReadDeadline(t time.Time) {
+	c.readDeadline.set(t)
+}
+
+// SetWriteDeadline sets the write deadline for subsequent ...Context calls.
+// A pending call is cancelled as soon as the deadline fires, whether it is
+// still sending its request or already reading the response. A zero value
+// disables the write deadline.
+func (c *Client) SetWriteDeadline(t time.Time) {
+	c.writeDeadline.set(t)
+}
+
+// withDeadlines returns a context derived from ctx that is cancelled when
+// either the read or the write deadline fires.
+func (c *Client) withDeadlines(ctx context.Context) (context.Context, context.CancelFunc) {
+	ctx, writeCancel := c.writeDeadline.withDeadline(ctx)
+	ctx, readCancel := c.readDeadline.withDeadline(ctx)
+	return ctx, func() {
+		readCancel()
+		writeCancel()
+	}
 }
 
 // MustNewClient attempts to create a new client, panics when an error has occurred.
-func MustNewClient() *Client {
-	if c, err := NewClient(); err != nil {
+func MustNewClient(opts ...ClientOption) *Client {
+	if c, err := NewClient(opts...); err != nil {
 		panic(err)
 	} else {
 		return c
@@ -50,15 +148,21 @@ func MustNewClient() *Client {
 
 // Login logs client into LMS.
 func (c *Client) Login(id, pw string) (bool, error) {
-	target := "https://lms.pknu.ac.kr/ilos/lo/login.acl"
-	params := url.Values{
-		"returnURL": {""},
-		"challenge": {""},
-		"response":  {""},
-		"usr_id":    {id},
-		"usr_pwd":   {pw},
-	}
-	resp, err := c.httpClient.PostForm(target, params)
+	return c.LoginContext(context.Background(), id, pw)
+}
+
+// LoginContext logs client into LMS, with given context.
+func (c *Client) LoginContext(ctx context.Context, id, pw string) (bool, error) {
+	ctx, cancel := c.withDeadlines(ctx)
+	defer cancel()
+
+	resp, err := c.newRequest(http.MethodPost, "/ilos/lo/login.acl").
+		set("returnURL", "").
+		set("challenge", "").
+		set("response", "").
+		set("usr_id", id).
+		set("usr_pwd", pw).
+		do(ctx)
 	if err != nil {
 		return false, err
 	}
@@ -87,8 +191,15 @@ func (c *Client) MustLogin(id, pw string) bool {
 
 // Logout logs client out from LMS.
 func (c *Client) Logout() error {
-	target := "http://lms.pknu.ac.kr/ilos/lo/logout.acl"
-	resp, err := c.httpClient.Get(target)
+	return c.LogoutContext(context.Background())
+}
+
+// LogoutContext logs client out from LMS, with given context.
+func (c *Client) LogoutContext(ctx context.Context) error {
+	ctx, cancel := c.withDeadlines(ctx)
+	defer cancel()
+
+	resp, err := c.newRequest(http.MethodGet, "/ilos/lo/logout.acl").do(ctx)
 	if err != nil {
 		return err
 	}
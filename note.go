@@ -1,41 +1,27 @@
 package pknulms
 
 import (
-	"encoding/json"
-	"errors"
-	"net/url"
+	"context"
+	"net/http"
 )
 
 // SendNote sends note to a person with given title and content.
 func (c *Client) SendNote(to, title, content string) error {
-	target := "http://lms.pknu.ac.kr/ilos/message/insert_pop.acl"
-	params := url.Values{
-		"TITLE":    {title},
-		"RECV_IDs": {to + "^"},
-		"CONTENT":  {content},
-		"encoding": {"utf-8"},
-	}
-	resp, err := c.httpClient.PostForm(target, params)
-	if err != nil {
-		return err
-	}
-	defer resp.Body.Close()
-
-	var result struct {
-		IsError bool   `json:"isError"`
-		Message string `json:"message"`
-	}
+	return c.SendNoteContext(context.Background(), to, title, content)
+}
 
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
-		return err
-	}
+// SendNoteContext sends note to a person with given title and content,
+// with given context.
+func (c *Client) SendNoteContext(ctx context.Context, to, title, content string) error {
+	ctx, cancel := c.withDeadlines(ctx)
+	defer cancel()
 
 	// Actually, it seems that an error cannot occur here
-	if result.IsError {
-		return errors.New(result.Message)
-	}
-
-	return nil
+	return c.newRequest(http.MethodPost, "/ilos/message/insert_pop.acl").
+		set("TITLE", title).
+		set("RECV_IDs", to+"^").
+		set("CONTENT", content).
+		decode(ctx)
 }
 
 // MustSendNote sends note to a person with given title and content,
@@ -1,11 +1,10 @@
 package pknulms
 
 import (
-	"encoding/json"
+	"context"
 	"errors"
 	"fmt"
-	"io/ioutil"
-	"net/url"
+	"net/http"
 	"regexp"
 	"strconv"
 	"strings"
@@ -45,18 +44,26 @@ func (n *Notification) String() string {
 // Note that start offset begins from 1 so the FIRST notification would be at offset 1, not 0.
 // Weirdly, it seems that the count must be >= 8 because of some mysterious reasons.
 func (c *Client) GetNotifications(start, count int) (result []*Notification, e error) {
+	return c.GetNotificationsContext(context.Background(), start, count)
+}
+
+// GetNotificationsContext returns a slice of notifications for given start offset and count,
+// with given context.
+// Note that start offset begins from 1 so the FIRST notification would be at offset 1, not 0.
+// Weirdly, it seems that the count must be >= 8 because of some mysterious reasons.
+func (c *Client) GetNotificationsContext(ctx context.Context, start, count int) (result []*Notification, e error) {
 	if count < 8 {
 		return nil, errors.New("Count must be >= 8")
 	}
 
-	target := "http://lms.pknu.ac.kr/ilos/mp/mypage_main_list.acl"
-	params := url.Values{
-		"start":    {strconv.Itoa(start)},
-		"display":  {strconv.Itoa(count)},
-		"GUBUN":    {""},
-		"encoding": {"utf-8"},
-	}
-	resp, err := c.httpClient.PostForm(target, params)
+	ctx, cancel := c.withDeadlines(ctx)
+	defer cancel()
+
+	resp, err := c.newRequest(http.MethodPost, "/ilos/mp/mypage_main_list.acl").
+		set("start", strconv.Itoa(start)).
+		set("display", strconv.Itoa(count)).
+		set("GUBUN", "").
+		do(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -95,7 +102,7 @@ func (c *Client) GetNotifications(start, count int) (result []*Notification, e e
 			e = err
 			return false
 		}
-		link = "http://lms.pknu.ac.kr" + href
+		link = c.baseURL + href
 
 		onclick, exists := a.Attr("onclick")
 		if !exists {
@@ -181,53 +188,38 @@ func (c *Client) MustGetNotificationsByPage(page int) []*Notification {
 	}
 }
 
-// prefetchArticle requests to prefetch an article.
-func (c *Client) prefetchArticle(key, returnURL string) error {
-	target := "http://lms.pknu.ac.kr/ilos/st/course/eclass_room2.acl"
-	params := url.Values{
-		"KJKEY":     {key},
-		"returnURI": {returnURL},
-		"encoding":  {"utf-8"},
-	}
-	resp, err := c.httpClient.PostForm(target, params)
-	if err != nil {
-		return err
-	}
-	defer resp.Body.Close()
-
-	type Result struct {
-		IsError     bool   `json:"isError"`
-		Message     string `json:"message"`
-		LectureType string `json:"lectType"`
-		ReturnURL   string `json:"returnURL"`
-	}
-	var result Result
-
-	body, err := ioutil.ReadAll(resp.Body)
-	if err != nil {
-		return err
-	}
-
-	if err := json.Unmarshal(body, &result); err != nil {
-		panic(err)
-	}
-	if result.IsError {
-		return errors.New(result.Message)
-	}
-
-	return nil
+// prefetchArticle requests to prefetch an article, with given context.
+func (c *Client) prefetchArticle(ctx context.Context, key, returnURL string) error {
+	return c.newRequest(http.MethodPost, "/ilos/st/course/eclass_room2.acl").
+		set("KJKEY", key).
+		set("returnURI", returnURL).
+		decode(ctx)
 }
 
 // GetNotificationContent returns content of given notification.
 // The result contains HTML codes, not plain text.
 func (c *Client) GetNotificationContent(n *Notification) (string, error) {
-	err := c.prefetchArticle(n.Lecture.Key,
-		strings.TrimPrefix(n.Link, "http://lms.pknu.ac.kr"))
+	return c.GetNotificationContentContext(context.Background(), n)
+}
+
+// GetNotificationContentContext returns content of given notification, with given context.
+// The result contains HTML codes, not plain text.
+func (c *Client) GetNotificationContentContext(ctx context.Context, n *Notification) (string, error) {
+	ctx, cancel := c.withDeadlines(ctx)
+	defer cancel()
+
+	err := c.prefetchArticle(ctx, n.Lecture.Key,
+		strings.TrimPrefix(n.Link, c.baseURL))
+	if err != nil {
+		return "", err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, n.Link+"&s=menu&acl=", nil)
 	if err != nil {
 		return "", err
 	}
 
-	resp, err := c.httpClient.Get(n.Link + "&s=menu&acl=")
+	resp, err := c.httpClient.Do(req)
 	if err != nil {
 		return "", err
 	}
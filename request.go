@@ -0,0 +1,98 @@
+package pknulms
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// requestBuilder centralizes the pieces every hand-rolled form post used to
+// repeat: the base URL, the encoding=utf-8 form field, and decoding of the
+// LMS's {isError, message} JSON envelope.
+type requestBuilder struct {
+	client   *Client
+	method   string
+	endpoint string
+	params   url.Values
+}
+
+// newRequest starts building a request against endpoint (a path relative to
+// the client's base URL) using method. POST requests get the standard
+// encoding=utf-8 form field the LMS expects on every form post.
+func (c *Client) newRequest(method, endpoint string) *requestBuilder {
+	params := url.Values{}
+	if method == http.MethodPost {
+		params.Set("encoding", "utf-8")
+	}
+	return &requestBuilder{
+		client:   c,
+		method:   method,
+		endpoint: endpoint,
+		params:   params,
+	}
+}
+
+// set adds a form field to the request, returning the builder for chaining.
+func (b *requestBuilder) set(key, value string) *requestBuilder {
+	b.params.Set(key, value)
+	return b
+}
+
+// do sends the request with ctx and returns the raw response.
+func (b *requestBuilder) do(ctx context.Context) (*http.Response, error) {
+	target := b.client.baseURL + b.endpoint
+
+	var body *strings.Reader
+	if b.method != http.MethodGet {
+		body = strings.NewReader(b.params.Encode())
+	} else if len(b.params) > 0 {
+		target += "?" + b.params.Encode()
+	}
+
+	var req *http.Request
+	var err error
+	if body != nil {
+		req, err = http.NewRequestWithContext(ctx, b.method, target, body)
+	} else {
+		req, err = http.NewRequestWithContext(ctx, b.method, target, nil)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	}
+	if b.client.userAgent != "" {
+		req.Header.Set("User-Agent", b.client.userAgent)
+	}
+
+	return b.client.httpClient.Do(req)
+}
+
+// lmsEnvelope is the {isError, message} JSON envelope most LMS endpoints
+// reply with.
+type lmsEnvelope struct {
+	IsError bool   `json:"isError"`
+	Message string `json:"message"`
+}
+
+// decode sends the request and decodes the LMS JSON envelope, returning an
+// *LMSError if the LMS reported one.
+func (b *requestBuilder) decode(ctx context.Context) error {
+	resp, err := b.do(ctx)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	var result lmsEnvelope
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return err
+	}
+	if result.IsError {
+		return &LMSError{Message: result.Message, Endpoint: b.endpoint}
+	}
+	return nil
+}
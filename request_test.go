@@ -0,0 +1,64 @@
+package pknulms
+
+import (
+	"net/http"
+	"net/http/cookiejar"
+	"net/http/httptest"
+	"testing"
+)
+
+func newTestClient(t *testing.T, handler http.Handler) (*Client, *httptest.Server) {
+	t.Helper()
+
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+
+	jar, err := cookiejar.New(nil)
+	if err != nil {
+		t.Fatalf("cookiejar.New: %v", err)
+	}
+
+	c, err := NewClient(
+		WithHTTPClient(&http.Client{Jar: jar}),
+		WithBaseURL(srv.URL),
+	)
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	return c, srv
+}
+
+func TestSendNoteSuccess(t *testing.T) {
+	c, _ := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/ilos/message/insert_pop.acl" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		w.Write([]byte(`{"isError":false,"message":""}`))
+	}))
+
+	if err := c.SendNote("someone", "title", "content"); err != nil {
+		t.Fatalf("SendNote: %v", err)
+	}
+}
+
+func TestSendNoteLMSError(t *testing.T) {
+	c, _ := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"isError":true,"message":"boom"}`))
+	}))
+
+	err := c.SendNote("someone", "title", "content")
+	if err == nil {
+		t.Fatal("expected an error, got nil")
+	}
+
+	lmsErr, ok := err.(*LMSError)
+	if !ok {
+		t.Fatalf("expected *LMSError, got %T: %v", err, err)
+	}
+	if lmsErr.Message != "boom" {
+		t.Errorf("Message = %q, want %q", lmsErr.Message, "boom")
+	}
+	if lmsErr.Endpoint != "/ilos/message/insert_pop.acl" {
+		t.Errorf("Endpoint = %q, want %q", lmsErr.Endpoint, "/ilos/message/insert_pop.acl")
+	}
+}
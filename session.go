@@ -0,0 +1,35 @@
+package pknulms
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+)
+
+// SaveSession writes the client's current cookies to w, so a later
+// LoadSession can restore the logged-in session without calling Login again.
+func (c *Client) SaveSession(w io.Writer) error {
+	u, err := url.Parse(c.baseURL)
+	if err != nil {
+		return err
+	}
+
+	return json.NewEncoder(w).Encode(c.httpClient.Jar.Cookies(u))
+}
+
+// LoadSession restores cookies previously written by SaveSession.
+func (c *Client) LoadSession(r io.Reader) error {
+	u, err := url.Parse(c.baseURL)
+	if err != nil {
+		return err
+	}
+
+	var cookies []*http.Cookie
+	if err := json.NewDecoder(r).Decode(&cookies); err != nil {
+		return err
+	}
+
+	c.httpClient.Jar.SetCookies(u, cookies)
+	return nil
+}
@@ -0,0 +1,120 @@
+package pknulms
+
+import (
+	"context"
+	"time"
+)
+
+// EventType identifies the kind of change StreamNotifications observed
+// between two polls.
+type EventType int
+
+// Event types emitted by StreamNotifications.
+const (
+	// EventCreate is emitted when a notification appears that was not seen before.
+	EventCreate EventType = iota
+	// EventUpdate is emitted when a previously seen notification's title,
+	// preview content or datetime changes, e.g. an assignment's "submitted" transition.
+	EventUpdate
+	// EventDelete is emitted when a previously seen notification no longer
+	// appears in the visible window.
+	EventDelete
+)
+
+// NotificationEvent represents a single change observed by StreamNotifications.
+type NotificationEvent struct {
+	Type         EventType
+	Notification *Notification
+}
+
+// StreamNotifications polls GetNotifications on the given interval and emits
+// a NotificationEvent each time a notification first appears, changes, or
+// disappears from the visible window. The returned channel is closed once
+// ctx is cancelled.
+func (c *Client) StreamNotifications(ctx context.Context, interval time.Duration) (<-chan NotificationEvent, error) {
+	events := make(chan NotificationEvent)
+
+	go func() {
+		defer close(events)
+
+		seen := make(map[int]*Notification)
+		backoff := interval
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			notifs, err := c.GetNotificationsContext(ctx, 1, 20)
+			if err != nil {
+				if ctx.Err() != nil {
+					return
+				}
+				backoff *= 2
+				if backoff > time.Hour {
+					backoff = time.Hour
+				}
+				select {
+				case <-time.After(backoff):
+				case <-ctx.Done():
+					return
+				}
+				continue
+			}
+			backoff = interval
+
+			present := make(map[int]bool, len(notifs))
+			for _, n := range notifs {
+				present[n.ID] = true
+
+				prev, ok := seen[n.ID]
+				seen[n.ID] = n
+				if !ok {
+					if !sendEvent(ctx, events, NotificationEvent{Type: EventCreate, Notification: n}) {
+						return
+					}
+				} else if notificationChanged(prev, n) {
+					if !sendEvent(ctx, events, NotificationEvent{Type: EventUpdate, Notification: n}) {
+						return
+					}
+				}
+			}
+
+			for id, last := range seen {
+				if present[id] {
+					continue
+				}
+				delete(seen, id)
+				if !sendEvent(ctx, events, NotificationEvent{Type: EventDelete, Notification: last}) {
+					return
+				}
+			}
+
+			select {
+			case <-ticker.C:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return events, nil
+}
+
+// notificationChanged reports whether the fields StreamNotifications watches
+// for changes differ between a and b, e.g. an assignment's "submitted" transition.
+func notificationChanged(a, b *Notification) bool {
+	return a.Title != b.Title ||
+		a.PreviewContent != b.PreviewContent ||
+		a.Datetime != b.Datetime ||
+		a.Submitted != b.Submitted
+}
+
+// sendEvent delivers e to events, returning false if ctx was cancelled first.
+func sendEvent(ctx context.Context, events chan<- NotificationEvent, e NotificationEvent) bool {
+	select {
+	case events <- e:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}